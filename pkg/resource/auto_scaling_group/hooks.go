@@ -15,11 +15,18 @@ package auto_scaling_group
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"slices"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	svcec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+
 	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
 	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
@@ -30,7 +37,9 @@ const (
 	ResourceType = "auto-scaling-group"
 )
 
-// getTags returns the tags for a given AutoScalingGroup
+// getTags returns the tags for a given AutoScalingGroup. GetTags never
+// mutates the resource, so it is never preflighted and always takes the
+// bare ASG name, as required by the DescribeTags auto-scaling-group filter.
 func (rm *resourceManager) getTags(
 	ctx context.Context,
 	resourceID string,
@@ -56,21 +65,107 @@ func (rm *resourceManager) syncTags(
 		return nil
 	}
 
-	resourceID := ""
-	if latest.ko.Spec.AutoScalingGroupName != nil {
-		resourceID = *latest.ko.Spec.AutoScalingGroupName
-	}
+	resourceID := asgResourceID(latest)
 
-	tagsSyncer := tags.NewSyncer(rm.sdkapi)
-	return tagsSyncer.SyncTags(
+	tagsSyncer := tags.NewSyncer(rm.sdkapi, tags.WithPreflight(tags.DefaultPreflight()))
+	if err := tagsSyncer.SyncTags(
 		ctx,
 		desired.ko.Spec.Tags,
 		latest.ko.Spec.Tags,
 		resourceID,
 		ResourceType,
+	); err != nil {
+		// A required-tags policy violation can only be resolved by a spec
+		// change, so surface it the same way customEnsureTags does rather
+		// than letting the generic controller requeue and retry forever.
+		if isRequiredTagPolicyViolation(err) {
+			return ackerr.NewTerminalError(err)
+		}
+		return err
+	}
+
+	return rm.syncLaunchTemplateTagPropagation(ctx, desired)
+}
+
+// isRequiredTagPolicyViolation returns true if err (or one of the errors it
+// wraps/joins) is a required-tags Policy violation raised by Policy.Enforce.
+func isRequiredTagPolicyViolation(err error) bool {
+	var missing *tags.MissingRequiredTagsError
+	var invalid *tags.InvalidRequiredTagValueError
+	return errors.As(err, &missing) || errors.As(err, &invalid)
+}
+
+// asgResourceID returns the identifier to pass as SyncTags' resourceID. When
+// preflight IAM simulation is configured, SyncTags requires the resource's
+// full ARN (it is passed straight through to SimulatePrincipalPolicy's
+// ResourceArns); otherwise the bare AutoScalingGroupName is used, matching
+// what the underlying CreateOrUpdateTags/DeleteTags calls expect.
+func asgResourceID(latest *resource) string {
+	name := ""
+	if latest.ko.Spec.AutoScalingGroupName != nil {
+		name = *latest.ko.Spec.AutoScalingGroupName
+	}
+	if tags.DefaultPreflight() == nil {
+		return name
+	}
+	if latest.ko.Status.ACKResourceMetadata != nil && latest.ko.Status.ACKResourceMetadata.ARN != nil {
+		return string(*latest.ko.Status.ACKResourceMetadata.ARN)
+	}
+	return name
+}
+
+// syncLaunchTemplateTagPropagation mirrors tags with PropagateAtLaunch set
+// onto the ASG's launch template when opted in via spec.tagPropagation,
+// rolling out a new launch template version if the propagated tags drift.
+func (rm *resourceManager) syncLaunchTemplateTagPropagation(
+	ctx context.Context,
+	desired *resource,
+) error {
+	propagation := desired.ko.Spec.TagPropagation
+	if propagation == nil {
+		return nil
+	}
+	lt := desired.ko.Spec.LaunchTemplate
+	if lt == nil || lt.LaunchTemplateID == nil {
+		// Tag propagation only applies to ASGs launched from a launch
+		// template; mixed instances policies and launch configurations
+		// are not supported.
+		return nil
+	}
+
+	cfg := tags.PropagationConfig{
+		Instance:         aws.ToBool(propagation.Instance),
+		Volume:           aws.ToBool(propagation.Volume),
+		NetworkInterface: aws.ToBool(propagation.NetworkInterface),
+	}
+
+	ec2Client, err := rm.ec2Client(ctx)
+	if err != nil {
+		return fmt.Errorf("constructing EC2 client for launch template tag propagation: %w", err)
+	}
+
+	propagationSyncer := tags.NewPropagationSyncer(ec2Client)
+	return propagationSyncer.SyncLaunchTemplateTags(
+		ctx,
+		*lt.LaunchTemplateID,
+		desired.ko.Spec.Tags,
+		cfg,
 	)
 }
 
+// ec2Client returns the EC2 API client used to mirror ASG tags onto launch
+// template tag specifications. EC2 is not this controller's primary AWS
+// service, so - unlike rm.sdkapi - it is not provided by the generated
+// resource manager factory; it is constructed here from the ambient AWS
+// config instead of being threaded through resourceManager's constructor.
+func (rm *resourceManager) ec2Client(ctx context.Context) (tags.EC2LaunchTemplateAPI, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svcec2.NewFromConfig(awsCfg), nil
+}
+
 // define custom type for Tags map string to svcapitypes.Tag
 type CustomTags map[string]*svcapitypes.Tag
 
@@ -115,18 +210,21 @@ func convertToOrderedCustomTags(tags []*svcapitypes.Tag) (CustomTags, []string)
 	return result, keyOrder
 }
 
-func ignoreSystemCustomTags(tags CustomTags, systemTags []string) {
-	for k := range tags {
+func ignoreSystemCustomTags(customTags CustomTags, systemTags []string) {
+	ignoreRules := tags.DefaultIgnoreRules()
+	for k := range customTags {
 		if strings.HasPrefix(k, "aws:") ||
-			slices.Contains(systemTags, k) {
-			delete(tags, k)
+			slices.Contains(systemTags, k) ||
+			ignoreRules.Matches(k) {
+			delete(customTags, k)
 		}
 	}
 }
 
 func syncAWSCustomTags(a CustomTags, b CustomTags) {
+	ignoreRules := tags.DefaultIgnoreRules()
 	for k := range b {
-		if strings.HasPrefix(k, "aws:") {
+		if strings.HasPrefix(k, "aws:") || ignoreRules.Matches(k) {
 			a[k] = b[k]
 		}
 	}
@@ -186,8 +284,15 @@ func (rm *resourceManager) customEnsureTags(
 	var existingTags []*svcapitypes.Tag
 	existingTags = r.ko.Spec.Tags
 	resourceTags, keyOrder := convertToOrderedCustomTags(existingTags)
-	tags := Merge(resourceTags, customDefaultTags)
-	r.ko.Spec.Tags = fromCustomTags(tags, keyOrder)
+	mergedTags := Merge(resourceTags, customDefaultTags)
+	r.ko.Spec.Tags = fromCustomTags(mergedTags, keyOrder)
+
+	enforced, err := tags.DefaultPolicy().Enforce(r.ko.Spec.Tags)
+	if err != nil {
+		return ackerr.NewTerminalError(err)
+	}
+	r.ko.Spec.Tags = enforced
+
 	return nil
 }
 
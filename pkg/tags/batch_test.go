@@ -0,0 +1,135 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+// makeTestTags returns n distinct tags with unique keys.
+func makeTestTags(n int) []*svcapitypes.Tag {
+	out := make([]*svcapitypes.Tag, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, &svcapitypes.Tag{
+			Key:               aws.String(fmt.Sprintf("key-%d", i)),
+			Value:             aws.String(fmt.Sprintf("value-%d", i)),
+			PropagateAtLaunch: aws.Bool(false),
+		})
+	}
+	return out
+}
+
+func expectedBatchCounts(total, batchSize int) []int {
+	var counts []int
+	for total > 0 {
+		n := batchSize
+		if n > total {
+			n = total
+		}
+		counts = append(counts, n)
+		total -= n
+	}
+	return counts
+}
+
+func TestSyncTags_CreateTags_Batching(t *testing.T) {
+	for _, tagCount := range []int{25, 26, 100} {
+		t.Run(fmt.Sprintf("%d tags", tagCount), func(t *testing.T) {
+			mockClient := &mockTagsClient{}
+			syncer := tags.NewSyncer(mockClient)
+
+			ctx := context.Background()
+			resourceID := "test-asg"
+			resourceType := "auto-scaling-group"
+
+			desired := makeTestTags(tagCount)
+			latest := []*svcapitypes.Tag{}
+
+			for _, count := range expectedBatchCounts(tagCount, tags.DefaultBatchSize) {
+				mockClient.On("CreateOrUpdateTags", ctx, mock.MatchedBy(func(input *svcsdk.CreateOrUpdateTagsInput) bool {
+					return len(input.Tags) == count
+				})).Return(&svcsdk.CreateOrUpdateTagsOutput{}, nil).Once()
+			}
+
+			err := syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+			assert.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncTags_DeleteTags_Batching(t *testing.T) {
+	for _, tagCount := range []int{25, 26, 100} {
+		t.Run(fmt.Sprintf("%d tags", tagCount), func(t *testing.T) {
+			mockClient := &mockTagsClient{}
+			syncer := tags.NewSyncer(mockClient)
+
+			ctx := context.Background()
+			resourceID := "test-asg"
+			resourceType := "auto-scaling-group"
+
+			desired := []*svcapitypes.Tag{}
+			latest := makeTestTags(tagCount)
+			for _, tag := range latest {
+				tag.ResourceID = aws.String(resourceID)
+				tag.ResourceType = aws.String(resourceType)
+			}
+
+			for _, count := range expectedBatchCounts(tagCount, tags.DefaultBatchSize) {
+				mockClient.On("DeleteTags", ctx, mock.MatchedBy(func(input *svcsdk.DeleteTagsInput) bool {
+					return len(input.Tags) == count
+				})).Return(&svcsdk.DeleteTagsOutput{}, nil).Once()
+			}
+
+			err := syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+			assert.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWithBatchSize(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	syncer := tags.NewSyncer(mockClient, tags.WithBatchSize(10))
+
+	ctx := context.Background()
+	resourceID := "test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := makeTestTags(25)
+	latest := []*svcapitypes.Tag{}
+
+	for _, count := range expectedBatchCounts(25, 10) {
+		mockClient.On("CreateOrUpdateTags", ctx, mock.MatchedBy(func(input *svcsdk.CreateOrUpdateTagsInput) bool {
+			return len(input.Tags) == count
+		})).Return(&svcsdk.CreateOrUpdateTagsOutput{}, nil).Once()
+	}
+
+	err := syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DriftReconcilerConfig holds the controller-wide flags governing the
+// periodic tag drift reconciler.
+type DriftReconcilerConfig struct {
+	// TagReconcilePeriod is the interval on which live ASG tags are checked
+	// for drift against the CR spec, independent of spec-triggered
+	// reconciliation.
+	TagReconcilePeriod time.Duration
+}
+
+// BindFlags registers the DriftReconcilerConfig's fields onto fs.
+func (c *DriftReconcilerConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(
+		&c.TagReconcilePeriod, "tag-reconcile-period", DefaultTagReconcilePeriod,
+		"The interval at which the controller checks live AutoScalingGroup tags for drift "+
+			"from the CR spec and repairs it.",
+	)
+}
+
+// PolicyConfig holds the controller-wide flags governing the required-tags
+// policy enforced on every AutoScalingGroup write.
+type PolicyConfig struct {
+	// RequiredTags holds the raw, repeatable --required-tag values, each of
+	// the form "key" or "key=value-regex".
+	RequiredTags []string
+}
+
+// BindFlags registers the PolicyConfig's fields onto fs.
+func (c *PolicyConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(
+		&c.RequiredTags, "required-tag", nil,
+		"A tag that must be present on every AutoScalingGroup, as \"key\" or "+
+			"\"key=value-regex\". Repeatable. When the raw value is a literal string it "+
+			"also serves as the default injected when the key is missing.",
+	)
+}
+
+// Policy builds a Policy from the parsed --required-tag flags.
+func (c *PolicyConfig) Policy() (*Policy, error) {
+	required := make([]RequiredTag, 0, len(c.RequiredTags))
+	for _, raw := range c.RequiredTags {
+		rt, err := ParseRequiredTagFlag(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing --required-tag flags: %w", err)
+		}
+		required = append(required, rt)
+	}
+	return NewPolicy(required...), nil
+}
+
+// PreflightConfig holds the controller-wide flags that opt into simulating
+// tag mutations against IAM before issuing them.
+type PreflightConfig struct {
+	// Enabled turns on preflight IAM policy simulation for tag mutations.
+	Enabled bool
+	// RoleARN is the ARN of the principal the controller runs as, passed as
+	// the PolicySourceArn to IAM SimulatePrincipalPolicy. Required when
+	// Enabled is true.
+	RoleARN string
+}
+
+// BindFlags registers the PreflightConfig's fields onto fs.
+func (c *PreflightConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(
+		&c.Enabled, "preflight-tag-permissions", false,
+		"Simulate tag mutations against the controller's IAM role via IAM SimulatePrincipalPolicy "+
+			"before issuing them, short-circuiting on a denied aws:RequestTag/aws:TagKeys condition.",
+	)
+	fs.StringVar(
+		&c.RoleARN, "preflight-tag-role-arn", "",
+		"The ARN of the IAM role the controller runs as, simulated against when "+
+			"--preflight-tag-permissions is enabled.",
+	)
+}
+
+// Preflight builds a Preflight from the parsed --preflight-tag-* flags,
+// using client to simulate policy evaluation. It returns nil if preflight
+// checking is not enabled.
+func (c *PreflightConfig) Preflight(client IAMSimulateAPI) *Preflight {
+	if !c.Enabled {
+		return nil
+	}
+	return NewPreflight(client, c.RoleARN)
+}
+
+// IgnoreRulesConfig holds the controller-wide flags governing which tag
+// keys the controller leaves alone so it can coexist with external
+// taggers.
+type IgnoreRulesConfig struct {
+	// Keys are exact tag keys the controller never manages.
+	Keys []string
+	// Prefixes are tag key prefixes the controller never manages.
+	Prefixes []string
+	// KeyRegexes are raw regular expressions matched against tag keys the
+	// controller never manages.
+	KeyRegexes []string
+}
+
+// BindFlags registers the IgnoreRulesConfig's fields onto fs.
+func (c *IgnoreRulesConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(
+		&c.Keys, "ignore-tag-key", nil,
+		"A tag key the controller must never add, update, or delete. Repeatable.",
+	)
+	fs.StringArrayVar(
+		&c.Prefixes, "ignore-tag-prefix", nil,
+		"A tag key prefix the controller must never add, update, or delete. Repeatable.",
+	)
+	fs.StringArrayVar(
+		&c.KeyRegexes, "ignore-tag-key-regex", nil,
+		"A regular expression matched against tag keys the controller must never add, update, or delete. Repeatable.",
+	)
+}
+
+// IgnoreRules builds an IgnoreRules from the parsed --ignore-tag-* flags.
+func (c *IgnoreRulesConfig) IgnoreRules() (*IgnoreRules, error) {
+	return NewIgnoreRules(c.Keys, c.Prefixes, c.KeyRegexes)
+}
@@ -0,0 +1,275 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+const (
+	// DefaultTagReconcilePeriod is the interval at which the
+	// DriftReconciler checks live ASG tags for drift when no period is
+	// otherwise configured.
+	DefaultTagReconcilePeriod = 10 * time.Minute
+
+	// TagsLastSyncedAnnotation is written onto an AutoScalingGroup after a
+	// successful drift reconciliation pass.
+	TagsLastSyncedAnnotation = "autoscaling.k8s.aws/tags-last-synced"
+
+	// TagDriftCorrectedConditionType is set on the resource's status
+	// conditions whenever drift is detected and repaired.
+	TagDriftCorrectedConditionType ackv1alpha1.ConditionType = "TagsDriftCorrected"
+
+	// tagDriftCorrectedEventReason is the reason used on the Kubernetes
+	// event emitted when drift is detected and repaired.
+	tagDriftCorrectedEventReason = "tag-drift-corrected"
+)
+
+var (
+	tagDriftDetectedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ackautoscaling_tag_drift_detected_total",
+		Help: "Total number of AutoScalingGroup reconciles where live tags drifted from the desired spec.",
+	})
+	tagDriftCorrectedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ackautoscaling_tag_drift_corrected_total",
+		Help: "Total number of AutoScalingGroup reconciles where tag drift was successfully corrected.",
+	})
+	tagDriftFailedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ackautoscaling_tag_drift_failed_total",
+		Help: "Total number of AutoScalingGroup reconciles where correcting tag drift failed.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		tagDriftDetectedTotal,
+		tagDriftCorrectedTotal,
+		tagDriftFailedTotal,
+	)
+}
+
+// DriftReconciler periodically compares the live tags on an ASG against the
+// tags recorded in its CR spec and repairs any drift caused by out-of-band
+// mutation (e.g. via the AWS console or a third-party tool). It runs
+// independently of the normal spec-triggered reconciliation loop.
+type DriftReconciler struct {
+	client     client.Client
+	syncer     *Syncer
+	recorder   record.EventRecorder
+	period     time.Duration
+	systemTags []string
+}
+
+// NewDriftReconciler returns a new DriftReconciler. period is the interval
+// on which live ASG tags are checked for drift; if zero, DefaultTagReconcilePeriod
+// is used. systemTags are AWS/CloudFormation-reserved or operator-reserved
+// keys that, like an "aws:" prefix, are stripped from both sides of the
+// comparison so they are never mistaken for drift and never passed to
+// DeleteTags/CreateOrUpdateTags - the same filtering ignoreSystemCustomTags
+// applies on the normal spec-triggered reconcile path.
+func NewDriftReconciler(
+	k8sClient client.Client,
+	syncer *Syncer,
+	recorder record.EventRecorder,
+	period time.Duration,
+	systemTags []string,
+) *DriftReconciler {
+	if period <= 0 {
+		period = DefaultTagReconcilePeriod
+	}
+	return &DriftReconciler{
+		client:     k8sClient,
+		syncer:     syncer,
+		recorder:   recorder,
+		period:     period,
+		systemTags: systemTags,
+	}
+}
+
+// Start runs the drift reconciliation loop until ctx is cancelled. It is
+// intended to be registered with the controller manager via
+// manager.Add(runnable).
+func (d *DriftReconciler) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("tag-drift-reconciler")
+	ticker := time.NewTicker(d.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.reconcileAll(ctx); err != nil {
+				log.Error(err, "failed to reconcile tag drift")
+			}
+		}
+	}
+}
+
+// reconcileAll lists every AutoScalingGroup CR known to the cluster and
+// repairs any tag drift found on each.
+func (d *DriftReconciler) reconcileAll(ctx context.Context) error {
+	var asgList svcapitypes.AutoScalingGroupList
+	if err := d.client.List(ctx, &asgList); err != nil {
+		return err
+	}
+
+	for i := range asgList.Items {
+		asg := &asgList.Items[i]
+		if err := d.reconcileOne(ctx, asg); err != nil {
+			tagDriftFailedTotal.Inc()
+			ctrl.LoggerFrom(ctx).Error(err, "failed to correct tag drift",
+				"autoScalingGroup", asg.Name, "namespace", asg.Namespace)
+		}
+	}
+	return nil
+}
+
+// reconcileOne checks a single AutoScalingGroup for tag drift and repairs it
+// if found, recording the outcome via metrics, a Kubernetes event, and a
+// status condition.
+func (d *DriftReconciler) reconcileOne(ctx context.Context, asg *svcapitypes.AutoScalingGroup) error {
+	if asg.Spec.AutoScalingGroupName == nil {
+		return nil
+	}
+	resourceID := *asg.Spec.AutoScalingGroupName
+
+	liveTags, err := d.syncer.GetTags(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+
+	// Reserved and ignored keys (AWS/CloudFormation-stamped "aws:" tags,
+	// operator systemTags, and keys matched by the ignore-tags config) are
+	// never part of the CR spec, so they must be stripped from the live
+	// side before comparing - otherwise every ASG looks perpetually
+	// drifted and SyncTags would try to delete tags AWS will reject.
+	desiredTags := filterReservedAndIgnored(asg.Spec.Tags, d.systemTags)
+	liveTags = filterReservedAndIgnored(liveTags, d.systemTags)
+
+	if tagsEqualSet(desiredTags, liveTags) {
+		return nil
+	}
+
+	tagDriftDetectedTotal.Inc()
+
+	if err := d.syncer.SyncTags(ctx, desiredTags, liveTags, resourceID, ResourceType); err != nil {
+		return err
+	}
+
+	tagDriftCorrectedTotal.Inc()
+	d.recorder.Eventf(asg, corev1.EventTypeNormal, tagDriftCorrectedEventReason,
+		"corrected tag drift on AutoScalingGroup %s", resourceID)
+
+	now := metav1.Now()
+	reason := "TagDriftCorrected"
+	message := "Detected and corrected tag drift between the live AutoScalingGroup and its spec"
+	setCondition(asg, &ackv1alpha1.Condition{
+		Type:               TagDriftCorrectedConditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             &reason,
+		Message:            &message,
+		LastTransitionTime: &now,
+	})
+	if asg.Annotations == nil {
+		asg.Annotations = map[string]string{}
+	}
+	asg.Annotations[TagsLastSyncedAnnotation] = now.Format(time.RFC3339)
+
+	if err := d.client.Update(ctx, asg); err != nil {
+		return err
+	}
+	// Conditions live in the /status subresource, which a plain Update call
+	// does not write to.
+	return d.client.Status().Update(ctx, asg)
+}
+
+// setCondition upserts cond into asg's status conditions, replacing any
+// existing condition of the same type.
+func setCondition(asg *svcapitypes.AutoScalingGroup, cond *ackv1alpha1.Condition) {
+	for i, existing := range asg.Status.Conditions {
+		if existing.Type == cond.Type {
+			asg.Status.Conditions[i] = cond
+			return
+		}
+	}
+	asg.Status.Conditions = append(asg.Status.Conditions, cond)
+}
+
+// filterReservedAndIgnored returns the tags in tags whose key does not carry
+// an "aws:" prefix, match one of systemTags, or match the controller's
+// configured ignore-tags rules. AWS and CloudFormation stamp reserved
+// "aws:autoscaling:*"/"aws:cloudformation:*" keys directly onto live ASGs,
+// and external taggers manage ignore-tags-matched keys, so neither ever
+// appears in the CR spec - they must be stripped from both sides before
+// comparing for drift, the same filtering ignoreSystemCustomTags/
+// syncAWSCustomTags apply on the normal spec-triggered reconcile path.
+func filterReservedAndIgnored(tags []*svcapitypes.Tag, systemTags []string) []*svcapitypes.Tag {
+	if len(tags) == 0 {
+		return tags
+	}
+	ignoreRules := DefaultIgnoreRules()
+	filtered := make([]*svcapitypes.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Key != nil {
+			key := *tag.Key
+			if strings.HasPrefix(key, "aws:") || slices.Contains(systemTags, key) || ignoreRules.Matches(key) {
+				continue
+			}
+		}
+		filtered = append(filtered, tag)
+	}
+	return filtered
+}
+
+// tagsEqualSet returns true if desired and live contain the same set of
+// tags, ignoring order.
+func tagsEqualSet(desired, live []*svcapitypes.Tag) bool {
+	if len(desired) != len(live) {
+		return false
+	}
+
+	liveByKey := map[string]*svcapitypes.Tag{}
+	for _, tag := range live {
+		if tag.Key != nil {
+			liveByKey[*tag.Key] = tag
+		}
+	}
+
+	for _, tag := range desired {
+		if tag.Key == nil {
+			return false
+		}
+		liveTag, ok := liveByKey[*tag.Key]
+		if !ok || !tagEquals(tag, liveTag) {
+			return false
+		}
+	}
+
+	return true
+}
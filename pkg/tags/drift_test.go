@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+type mockDriftTagsClient struct {
+	mock.Mock
+}
+
+func (m *mockDriftTagsClient) CreateOrUpdateTags(
+	ctx context.Context,
+	input *svcsdk.CreateOrUpdateTagsInput,
+	opts ...func(*svcsdk.Options),
+) (*svcsdk.CreateOrUpdateTagsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcsdk.CreateOrUpdateTagsOutput), args.Error(1)
+}
+
+func (m *mockDriftTagsClient) DeleteTags(
+	ctx context.Context,
+	input *svcsdk.DeleteTagsInput,
+	opts ...func(*svcsdk.Options),
+) (*svcsdk.DeleteTagsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcsdk.DeleteTagsOutput), args.Error(1)
+}
+
+func (m *mockDriftTagsClient) DescribeTags(
+	ctx context.Context,
+	input *svcsdk.DescribeTagsInput,
+	opts ...func(*svcsdk.Options),
+) (*svcsdk.DescribeTagsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcsdk.DescribeTagsOutput), args.Error(1)
+}
+
+func TestFilterReservedAndIgnored_StripsAWSPrefixSystemTagsAndIgnoreRules(t *testing.T) {
+	rules, err := NewIgnoreRules([]string{"kops.k8s.io/managed"}, nil, nil)
+	assert.NoError(t, err)
+	SetDefaultIgnoreRules(rules)
+	defer SetDefaultIgnoreRules(nil)
+
+	tags := []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("prod")},
+		{Key: aws.String("aws:autoscaling:groupName"), Value: aws.String("my-asg")},
+		{Key: aws.String("operator:managed-by"), Value: aws.String("karpenter")},
+		{Key: aws.String("kops.k8s.io/managed"), Value: aws.String("true")},
+	}
+
+	filtered := filterReservedAndIgnored(tags, []string{"operator:managed-by"})
+
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Environment", *filtered[0].Key)
+}
+
+// TestDriftReconciler_ReconcileOne_IgnoresReservedTagDrift verifies that a
+// live ASG carrying only reserved "aws:"-prefixed tags beyond what's in the
+// CR spec is never reported as drifted, and SyncTags is never asked to
+// delete those reserved tags.
+func TestDriftReconciler_ReconcileOne_IgnoresReservedTagDrift(t *testing.T) {
+	mockClient := &mockDriftTagsClient{}
+	mockClient.On("DescribeTags", mock.Anything, mock.Anything).Return(&svcsdk.DescribeTagsOutput{
+		Tags: []svcsdktypes.TagDescription{
+			{Key: aws.String("Environment"), Value: aws.String("prod")},
+			{Key: aws.String("aws:autoscaling:groupName"), Value: aws.String("my-asg")},
+		},
+	}, nil).Once()
+
+	asg := &svcapitypes.AutoScalingGroup{
+		Spec: svcapitypes.AutoScalingGroupSpec{
+			AutoScalingGroupName: aws.String("my-asg"),
+			Tags: []*svcapitypes.Tag{
+				{Key: aws.String("Environment"), Value: aws.String("prod")},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithObjects(asg).Build()
+	syncer := NewSyncer(mockClient)
+	recorder := record.NewFakeRecorder(1)
+	reconciler := NewDriftReconciler(k8sClient, syncer, recorder, 0, nil)
+
+	err := reconciler.reconcileOne(context.Background(), asg)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeleteTags", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "CreateOrUpdateTags", mock.Anything, mock.Anything)
+
+	select {
+	case <-recorder.Events:
+		t.Fatal("expected no drift-corrected event when only reserved tags differ")
+	default:
+	}
+}
+
+// TestDriftReconciler_ReconcileOne_SystemTagsIgnored verifies that keys
+// passed as systemTags are stripped from the live side the same way an
+// "aws:" prefix is, even without an ignore-rules match.
+func TestDriftReconciler_ReconcileOne_SystemTagsIgnored(t *testing.T) {
+	mockClient := &mockDriftTagsClient{}
+	mockClient.On("DescribeTags", mock.Anything, mock.Anything).Return(&svcsdk.DescribeTagsOutput{
+		Tags: []svcsdktypes.TagDescription{
+			{Key: aws.String("Environment"), Value: aws.String("prod")},
+			{Key: aws.String("kops.k8s.io/instancegroup"), Value: aws.String("nodes")},
+		},
+	}, nil).Once()
+
+	asg := &svcapitypes.AutoScalingGroup{
+		Spec: svcapitypes.AutoScalingGroupSpec{
+			AutoScalingGroupName: aws.String("my-asg"),
+			Tags: []*svcapitypes.Tag{
+				{Key: aws.String("Environment"), Value: aws.String("prod")},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithObjects(asg).Build()
+	syncer := NewSyncer(mockClient)
+	recorder := record.NewFakeRecorder(1)
+	reconciler := NewDriftReconciler(k8sClient, syncer, recorder, 0, []string{"kops.k8s.io/instancegroup"})
+
+	err := reconciler.reconcileOne(context.Background(), asg)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeleteTags", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "CreateOrUpdateTags", mock.Anything, mock.Anything)
+}
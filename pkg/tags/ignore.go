@@ -0,0 +1,113 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+// IgnoreRules identifies tag keys the controller should leave entirely
+// alone, so it can coexist with external taggers (e.g. kOps, Karpenter,
+// backup tooling) that stamp their own tags on ASGs without ACK fighting to
+// remove them.
+type IgnoreRules struct {
+	keys       map[string]bool
+	prefixes   []string
+	keyRegexes []*regexp.Regexp
+}
+
+// NewIgnoreRules compiles keyRegexPatterns and returns an IgnoreRules
+// matching any of keys, keyPrefixes, or keyRegexPatterns.
+func NewIgnoreRules(keys []string, keyPrefixes []string, keyRegexPatterns []string) (*IgnoreRules, error) {
+	keySet := map[string]bool{}
+	for _, k := range keys {
+		keySet[k] = true
+	}
+
+	regexes := make([]*regexp.Regexp, 0, len(keyRegexPatterns))
+	for _, pattern := range keyRegexPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexes = append(regexes, re)
+	}
+
+	return &IgnoreRules{keys: keySet, prefixes: keyPrefixes, keyRegexes: regexes}, nil
+}
+
+// Matches returns true if key should be ignored by the controller.
+func (r *IgnoreRules) Matches(key string) bool {
+	if r == nil {
+		return false
+	}
+	if r.keys[key] {
+		return true
+	}
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, re := range r.keyRegexes {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the tags in tags whose key does not match r.
+func (r *IgnoreRules) Filter(tags []*svcapitypes.Tag) []*svcapitypes.Tag {
+	if r == nil || len(tags) == 0 {
+		return tags
+	}
+
+	filtered := make([]*svcapitypes.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Key != nil && r.Matches(*tag.Key) {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+	return filtered
+}
+
+var (
+	defaultIgnoreRulesMu sync.RWMutex
+	defaultIgnoreRules   *IgnoreRules
+)
+
+// SetDefaultIgnoreRules installs the controller-wide ignore-tags
+// configuration, constructed once at startup from the --ignore-tag-key,
+// --ignore-tag-prefix, and --ignore-tag-key-regex flags. Syncers created
+// without an explicit WithIgnoreRules option fall back to it, as does
+// CustomFilterSystemTags.
+func SetDefaultIgnoreRules(r *IgnoreRules) {
+	defaultIgnoreRulesMu.Lock()
+	defer defaultIgnoreRulesMu.Unlock()
+	defaultIgnoreRules = r
+}
+
+// DefaultIgnoreRules returns the controller-wide ignore-tags configuration
+// installed via SetDefaultIgnoreRules, or nil if none has been configured.
+func DefaultIgnoreRules() *IgnoreRules {
+	defaultIgnoreRulesMu.RLock()
+	defer defaultIgnoreRulesMu.RUnlock()
+	return defaultIgnoreRules
+}
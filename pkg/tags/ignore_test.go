@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+func TestIgnoreRules_Matches(t *testing.T) {
+	rules, err := tags.NewIgnoreRules(
+		[]string{"ExactKey"},
+		[]string{"kops.k8s.io/"},
+		[]string{`^karpenter\.sh/.*`},
+	)
+	assert.NoError(t, err)
+
+	assert.True(t, rules.Matches("ExactKey"))
+	assert.True(t, rules.Matches("kops.k8s.io/instancegroup"))
+	assert.True(t, rules.Matches("karpenter.sh/nodepool"))
+	assert.False(t, rules.Matches("Environment"))
+}
+
+func TestIgnoreRules_InvalidRegex(t *testing.T) {
+	_, err := tags.NewIgnoreRules(nil, nil, []string{"["})
+	assert.Error(t, err)
+}
+
+func TestSyncTags_IgnoreRules_ExcludedFromDiff(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	rules, err := tags.NewIgnoreRules([]string{"kops.k8s.io/managed"}, nil, nil)
+	assert.NoError(t, err)
+	syncer := tags.NewSyncer(mockClient, tags.WithIgnoreRules(rules))
+
+	ctx := context.Background()
+	resourceID := "test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test-asg"), PropagateAtLaunch: aws.Bool(true)},
+	}
+	latest := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test-asg"), PropagateAtLaunch: aws.Bool(true), ResourceID: aws.String(resourceID), ResourceType: aws.String(resourceType)},
+		{Key: aws.String("kops.k8s.io/managed"), Value: aws.String("true"), PropagateAtLaunch: aws.Bool(true), ResourceID: aws.String(resourceID), ResourceType: aws.String(resourceType)},
+	}
+
+	err = syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "DeleteTags", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "CreateOrUpdateTags", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+// InstallDefaults builds the controller-wide Policy, Preflight, and
+// IgnoreRules from the parsed --required-tag, --preflight-tag-*, and
+// --ignore-tag-* flags and installs them via SetDefaultPolicy,
+// SetDefaultPreflight, and SetDefaultIgnoreRules. Call this once during
+// controller startup, after flags have been parsed and before the manager
+// starts reconciling, so that Syncers and customEnsureTags/
+// CustomFilterSystemTags constructed without an explicit option pick up the
+// flag-configured behavior instead of silently falling back to nil.
+func InstallDefaults(
+	policyCfg *PolicyConfig,
+	preflightCfg *PreflightConfig,
+	ignoreCfg *IgnoreRulesConfig,
+	iamClient IAMSimulateAPI,
+) error {
+	policy, err := policyCfg.Policy()
+	if err != nil {
+		return err
+	}
+	SetDefaultPolicy(policy)
+
+	ignoreRules, err := ignoreCfg.IgnoreRules()
+	if err != nil {
+		return err
+	}
+	SetDefaultIgnoreRules(ignoreRules)
+
+	SetDefaultPreflight(preflightCfg.Preflight(iamClient))
+
+	return nil
+}
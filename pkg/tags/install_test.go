@@ -0,0 +1,50 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallDefaults_WiresParsedFlagsIntoPackageDefaults(t *testing.T) {
+	defer tags.SetDefaultPolicy(nil)
+	defer tags.SetDefaultPreflight(nil)
+	defer tags.SetDefaultIgnoreRules(nil)
+
+	policyCfg := &tags.PolicyConfig{RequiredTags: []string{"CostCenter"}}
+	preflightCfg := &tags.PreflightConfig{Enabled: true, RoleARN: "arn:aws:iam::123456789012:role/ack-role"}
+	ignoreCfg := &tags.IgnoreRulesConfig{Keys: []string{"managed-by-other-tool"}}
+
+	err := tags.InstallDefaults(policyCfg, preflightCfg, ignoreCfg, &mockIAMClient{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, tags.DefaultPolicy())
+	assert.NotNil(t, tags.DefaultPreflight())
+	assert.NotNil(t, tags.DefaultIgnoreRules())
+}
+
+func TestInstallDefaults_PropagatesPolicyParseError(t *testing.T) {
+	defer tags.SetDefaultPolicy(nil)
+
+	policyCfg := &tags.PolicyConfig{RequiredTags: []string{"=invalid"}}
+	preflightCfg := &tags.PreflightConfig{}
+	ignoreCfg := &tags.IgnoreRulesConfig{}
+
+	err := tags.InstallDefaults(policyCfg, preflightCfg, ignoreCfg, &mockIAMClient{})
+
+	assert.Error(t, err)
+}
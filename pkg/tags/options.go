@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+// DefaultBatchSize is the maximum number of tags the AutoScaling API will
+// accept in a single CreateOrUpdateTags or DeleteTags call.
+const DefaultBatchSize = 25
+
+// Option customizes a Syncer returned by NewSyncer.
+type Option func(*Syncer)
+
+// WithBatchSize overrides the number of tags sent per CreateOrUpdateTags or
+// DeleteTags call. size must be between 1 and DefaultBatchSize; values
+// outside that range are ignored.
+func WithBatchSize(size int) Option {
+	return func(s *Syncer) {
+		if size > 0 && size <= DefaultBatchSize {
+			s.batchSize = size
+		}
+	}
+}
+
+// WithPolicy attaches a required-tags Policy to the Syncer. SyncTags will
+// enforce it on every call: injecting default values for missing required
+// tags, rejecting writes that are missing a required tag with no default,
+// and refusing to delete a required tag even if it is absent from desired.
+func WithPolicy(p *Policy) Option {
+	return func(s *Syncer) {
+		s.policy = p
+	}
+}
+
+// WithPreflight attaches an IAM Preflight to the Syncer. Before issuing a
+// CreateOrUpdateTags or DeleteTags call, SyncTags simulates the call
+// against the controller's IAM role and short-circuits with a
+// *PreflightDeniedError if the simulation reports a denial.
+func WithPreflight(p *Preflight) Option {
+	return func(s *Syncer) {
+		s.preflight = p
+	}
+}
+
+// WithIgnoreRules attaches IgnoreRules to the Syncer. SyncTags excludes any
+// matching tag key from both the create/update and delete diffs, so tags
+// stamped by external tooling are left untouched.
+func WithIgnoreRules(r *IgnoreRules) Option {
+	return func(s *Syncer) {
+		s.ignoreRules = r
+	}
+}
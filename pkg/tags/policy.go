@@ -0,0 +1,194 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+// RequiredTag describes a single entry parsed from a repeated
+// --required-tag=key[=value-regex] flag.
+type RequiredTag struct {
+	// Key is the tag key that must be present on every synced ASG.
+	Key string
+	// ValueRegex, if non-nil, is matched against the tag's value whenever
+	// the key is present in the desired tag set.
+	ValueRegex *regexp.Regexp
+	// Default is injected as the tag's value when Key is absent from the
+	// desired tag set. It is derived from the raw value passed alongside
+	// the key on the command line, so a literal value both validates and
+	// defaults that key. A value containing regular expression
+	// metacharacters sets no default.
+	Default *string
+}
+
+// Policy enforces a set of required tags on AutoScalingGroup writes.
+type Policy struct {
+	required []RequiredTag
+}
+
+// NewPolicy returns a Policy enforcing the given required tags.
+func NewPolicy(required ...RequiredTag) *Policy {
+	return &Policy{required: required}
+}
+
+// ParseRequiredTagFlag parses a single --required-tag value of the form
+// "key" or "key=value-regex" into a RequiredTag.
+func ParseRequiredTagFlag(raw string) (RequiredTag, error) {
+	key, value, hasValue := strings.Cut(raw, "=")
+	if key == "" {
+		return RequiredTag{}, fmt.Errorf("invalid --required-tag %q: key must not be empty", raw)
+	}
+	if !hasValue {
+		return RequiredTag{Key: key}, nil
+	}
+
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return RequiredTag{}, fmt.Errorf("invalid --required-tag %q: %w", raw, err)
+	}
+	rt := RequiredTag{Key: key, ValueRegex: re}
+	if regexp.QuoteMeta(value) == value {
+		rt.Default = aws.String(value)
+	}
+	return rt, nil
+}
+
+// MissingRequiredTagsError is returned by Policy.Enforce when one or more
+// required tags are absent from the desired tag set and have no default
+// value to fall back to. Callers should surface this as a terminal
+// condition, since retrying without spec changes will not resolve it.
+type MissingRequiredTagsError struct {
+	Keys []string
+}
+
+func (e *MissingRequiredTagsError) Error() string {
+	return fmt.Sprintf("missing required tag(s): %s", strings.Join(e.Keys, ", "))
+}
+
+// InvalidRequiredTagValueError is returned by Policy.Enforce when a
+// required tag is present but its value does not match the configured
+// ValueRegex.
+type InvalidRequiredTagValueError struct {
+	Key   string
+	Value string
+}
+
+func (e *InvalidRequiredTagValueError) Error() string {
+	return fmt.Sprintf("tag %q has value %q which does not satisfy the required-tag policy", e.Key, e.Value)
+}
+
+// Enforce validates desired against the policy, injecting default values
+// for any missing required tags that have one. It returns the (possibly
+// updated) desired tag set. If a required key is missing with no default,
+// or present with a value that fails its ValueRegex, it returns an error
+// describing every violation found.
+func (p *Policy) Enforce(desired []*svcapitypes.Tag) ([]*svcapitypes.Tag, error) {
+	if p == nil || len(p.required) == 0 {
+		return desired, nil
+	}
+
+	desiredByKey := map[string]*svcapitypes.Tag{}
+	for _, tag := range desired {
+		if tag.Key != nil {
+			desiredByKey[*tag.Key] = tag
+		}
+	}
+
+	var missing []string
+	var invalid []error
+	result := desired
+	for _, req := range p.required {
+		tag, present := desiredByKey[req.Key]
+		if !present {
+			if req.Default != nil {
+				key := req.Key
+				value := *req.Default
+				result = append(result, &svcapitypes.Tag{Key: &key, Value: &value})
+				continue
+			}
+			missing = append(missing, req.Key)
+			continue
+		}
+		if req.ValueRegex != nil && tag.Value != nil && !req.ValueRegex.MatchString(*tag.Value) {
+			invalid = append(invalid, &InvalidRequiredTagValueError{Key: req.Key, Value: *tag.Value})
+		}
+	}
+
+	if len(missing) > 0 || len(invalid) > 0 {
+		var errs []error
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			errs = append(errs, &MissingRequiredTagsError{Keys: missing})
+		}
+		errs = append(errs, invalid...)
+		return desired, errors.Join(errs...)
+	}
+
+	return result, nil
+}
+
+// ProtectRequired removes any required tag from toDelete, preventing a
+// desired tag set that simply omits a mandated key (rather than explicitly
+// overriding it) from stripping that tag off the live resource.
+func (p *Policy) ProtectRequired(toDelete []*svcapitypes.Tag) []*svcapitypes.Tag {
+	if p == nil || len(p.required) == 0 || len(toDelete) == 0 {
+		return toDelete
+	}
+
+	required := map[string]bool{}
+	for _, req := range p.required {
+		required[req.Key] = true
+	}
+
+	filtered := make([]*svcapitypes.Tag, 0, len(toDelete))
+	for _, tag := range toDelete {
+		if tag.Key != nil && required[*tag.Key] {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+	return filtered
+}
+
+var (
+	defaultPolicyMu sync.RWMutex
+	defaultPolicy   *Policy
+)
+
+// SetDefaultPolicy installs the controller-wide required-tags policy,
+// constructed once at startup from the repeated --required-tag flags.
+// Syncers created without an explicit WithPolicy option fall back to it.
+func SetDefaultPolicy(p *Policy) {
+	defaultPolicyMu.Lock()
+	defer defaultPolicyMu.Unlock()
+	defaultPolicy = p
+}
+
+// DefaultPolicy returns the controller-wide required-tags policy installed
+// via SetDefaultPolicy, or nil if none has been configured.
+func DefaultPolicy() *Policy {
+	defaultPolicyMu.RLock()
+	defer defaultPolicyMu.RUnlock()
+	return defaultPolicy
+}
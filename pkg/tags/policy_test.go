@@ -0,0 +1,153 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+func TestParseRequiredTagFlag(t *testing.T) {
+	rt, err := tags.ParseRequiredTagFlag("CostCenter")
+	assert.NoError(t, err)
+	assert.Equal(t, "CostCenter", rt.Key)
+	assert.Nil(t, rt.ValueRegex)
+	assert.Nil(t, rt.Default)
+
+	rt, err = tags.ParseRequiredTagFlag("Environment=prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "Environment", rt.Key)
+	assert.NotNil(t, rt.ValueRegex)
+	assert.Equal(t, "prod", *rt.Default)
+
+	_, err = tags.ParseRequiredTagFlag("=prod")
+	assert.Error(t, err)
+
+	_, err = tags.ParseRequiredTagFlag("Team=[")
+	assert.Error(t, err)
+}
+
+func TestPolicy_Enforce_InjectsDefault(t *testing.T) {
+	policy := tags.NewPolicy(tags.RequiredTag{Key: "Environment", Default: aws.String("prod")})
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("my-asg")},
+	}
+
+	result, err := policy.Enforce(desired)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	var found bool
+	for _, tag := range result {
+		if *tag.Key == "Environment" {
+			found = true
+			assert.Equal(t, "prod", *tag.Value)
+		}
+	}
+	assert.True(t, found, "expected Environment tag to be injected")
+}
+
+func TestPolicy_Enforce_MissingWithoutDefault(t *testing.T) {
+	policy := tags.NewPolicy(tags.RequiredTag{Key: "CostCenter"})
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("my-asg")},
+	}
+
+	_, err := policy.Enforce(desired)
+	assert.Error(t, err)
+
+	var missingErr *tags.MissingRequiredTagsError
+	assert.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []string{"CostCenter"}, missingErr.Keys)
+}
+
+func TestPolicy_Enforce_InvalidValue(t *testing.T) {
+	rt, err := tags.ParseRequiredTagFlag("Environment=^(prod|staging)$")
+	assert.NoError(t, err)
+	policy := tags.NewPolicy(rt)
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("dev")},
+	}
+
+	_, err = policy.Enforce(desired)
+	assert.Error(t, err)
+
+	var invalidErr *tags.InvalidRequiredTagValueError
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestPolicy_ProtectRequired_BlocksDelete(t *testing.T) {
+	policy := tags.NewPolicy(tags.RequiredTag{Key: "CostCenter"})
+
+	toDelete := []*svcapitypes.Tag{
+		{Key: aws.String("CostCenter"), Value: aws.String("123")},
+		{Key: aws.String("Scratch"), Value: aws.String("x")},
+	}
+
+	filtered := policy.ProtectRequired(toDelete)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Scratch", *filtered[0].Key)
+}
+
+func TestSyncTags_PolicyBlocksDeleteOfRequiredTag(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	policy := tags.NewPolicy(tags.RequiredTag{Key: "CostCenter"})
+	syncer := tags.NewSyncer(mockClient, tags.WithPolicy(policy))
+
+	ctx := context.Background()
+	resourceID := "test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := []*svcapitypes.Tag{}
+	latest := []*svcapitypes.Tag{
+		{Key: aws.String("CostCenter"), Value: aws.String("123"), ResourceID: aws.String(resourceID), ResourceType: aws.String(resourceType)},
+	}
+
+	err := syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+	// CostCenter is missing from desired and has no default, so the sync
+	// fails before any deletion is attempted.
+	assert.Error(t, err)
+	mockClient.AssertNotCalled(t, "DeleteTags", mock.Anything, mock.Anything)
+}
+
+func TestSyncTags_PolicyFailsOnMissingRequiredTag(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	policy := tags.NewPolicy(tags.RequiredTag{Key: "CostCenter"})
+	syncer := tags.NewSyncer(mockClient, tags.WithPolicy(policy))
+
+	ctx := context.Background()
+	resourceID := "test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("my-asg")},
+	}
+	latest := []*svcapitypes.Tag{}
+
+	err := syncer.SyncTags(ctx, desired, latest, resourceID, resourceType)
+
+	assert.Error(t, err)
+	mockClient.AssertNotCalled(t, "CreateOrUpdateTags", mock.Anything, mock.Anything)
+}
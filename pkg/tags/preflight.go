@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svciam "github.com/aws/aws-sdk-go-v2/service/iam"
+	svciamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+// autoscalingCreateOrUpdateTagsAction and autoscalingDeleteTagsAction are
+// the IAM action names simulated before mutating ASG tags.
+const (
+	autoscalingCreateOrUpdateTagsAction = "autoscaling:CreateOrUpdateTags"
+	autoscalingDeleteTagsAction         = "autoscaling:DeleteTags"
+)
+
+// IAMSimulateAPI represents the subset of the IAM API used to preflight tag
+// mutations against the controller's attached policies.
+type IAMSimulateAPI interface {
+	SimulatePrincipalPolicy(context.Context, *svciam.SimulatePrincipalPolicyInput, ...func(*svciam.Options)) (*svciam.SimulatePrincipalPolicyOutput, error)
+}
+
+// Preflight checks whether the controller's IAM role is permitted to make a
+// pending tag mutation before issuing it, surfacing `aws:RequestTag/*` and
+// `aws:TagKeys` condition denials ahead of time rather than as an API error.
+// No `aws:ResourceTag/*` context is supplied to the simulation.
+type Preflight struct {
+	client  IAMSimulateAPI
+	roleARN string
+}
+
+// NewPreflight returns a Preflight that simulates policy evaluation for
+// roleARN, the ARN of the principal the controller runs as.
+func NewPreflight(client IAMSimulateAPI, roleARN string) *Preflight {
+	return &Preflight{client: client, roleARN: roleARN}
+}
+
+// PreflightDeniedError is returned when IAM policy simulation reports that
+// a pending tag mutation would be denied.
+type PreflightDeniedError struct {
+	Action       string
+	ResourceARN  string
+	DecisionType string
+}
+
+func (e *PreflightDeniedError) Error() string {
+	return fmt.Sprintf("preflight check denied %s on %s: %s", e.Action, e.ResourceARN, e.DecisionType)
+}
+
+// checkTagMutation simulates action against resourceARN with the given tags
+// attached as aws:TagKeys and aws:RequestTag/<key> context (no
+// aws:ResourceTag/<key> entries are sent), returning a *PreflightDeniedError
+// if any evaluation result is not "allowed".
+func (p *Preflight) checkTagMutation(
+	ctx context.Context,
+	action string,
+	resourceARN string,
+	tags []*svcapitypes.Tag,
+) error {
+	if p == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	contextEntries := []svciamtypes.ContextEntry{
+		{
+			ContextKeyName:   aws.String("aws:TagKeys"),
+			ContextKeyType:   svciamtypes.ContextKeyTypeEnumStringList,
+			ContextKeyValues: nil, // populated below once keys is known
+		},
+	}
+	for _, tag := range tags {
+		if tag.Key == nil {
+			continue
+		}
+		keys = append(keys, *tag.Key)
+		if tag.Value != nil {
+			contextEntries = append(contextEntries, svciamtypes.ContextEntry{
+				ContextKeyName:   aws.String(fmt.Sprintf("aws:RequestTag/%s", *tag.Key)),
+				ContextKeyType:   svciamtypes.ContextKeyTypeEnumString,
+				ContextKeyValues: []string{*tag.Value},
+			})
+		}
+	}
+	contextEntries[0].ContextKeyValues = keys
+
+	out, err := p.client.SimulatePrincipalPolicy(ctx, &svciam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(p.roleARN),
+		ActionNames:     []string{action},
+		ResourceArns:    []string{resourceARN},
+		ContextEntries:  contextEntries,
+	})
+	if err != nil {
+		return fmt.Errorf("simulating IAM policy for %s on %s: %w", action, resourceARN, err)
+	}
+
+	for _, result := range out.EvaluationResults {
+		if result.EvalDecision != svciamtypes.PolicyEvaluationDecisionTypeAllowed {
+			return &PreflightDeniedError{
+				Action:       action,
+				ResourceARN:  resourceARN,
+				DecisionType: string(result.EvalDecision),
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	defaultPreflightMu sync.RWMutex
+	defaultPreflight   *Preflight
+)
+
+// SetDefaultPreflight installs the controller-wide IAM preflight checker,
+// constructed once at startup from the --preflight-tag-permissions flag.
+// Syncers created without an explicit WithPreflight option fall back to it.
+func SetDefaultPreflight(p *Preflight) {
+	defaultPreflightMu.Lock()
+	defer defaultPreflightMu.Unlock()
+	defaultPreflight = p
+}
+
+// DefaultPreflight returns the controller-wide IAM preflight checker
+// installed via SetDefaultPreflight, or nil if none has been configured.
+func DefaultPreflight() *Preflight {
+	defaultPreflightMu.RLock()
+	defer defaultPreflightMu.RUnlock()
+	return defaultPreflight
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	svciam "github.com/aws/aws-sdk-go-v2/service/iam"
+	svciamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+type mockIAMClient struct {
+	mock.Mock
+}
+
+func (m *mockIAMClient) SimulatePrincipalPolicy(
+	ctx context.Context,
+	input *svciam.SimulatePrincipalPolicyInput,
+	opts ...func(*svciam.Options),
+) (*svciam.SimulatePrincipalPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svciam.SimulatePrincipalPolicyOutput), args.Error(1)
+}
+
+func TestSyncTags_PreflightAllows(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	mockIAM := &mockIAMClient{}
+
+	mockIAM.On("SimulatePrincipalPolicy", mock.Anything, mock.Anything).Return(
+		&svciam.SimulatePrincipalPolicyOutput{
+			EvaluationResults: []svciamtypes.EvaluationResult{
+				{EvalDecision: svciamtypes.PolicyEvaluationDecisionTypeAllowed},
+			},
+		}, nil)
+
+	preflight := tags.NewPreflight(mockIAM, "arn:aws:iam::123456789012:role/ack-role")
+	syncer := tags.NewSyncer(mockClient, tags.WithPreflight(preflight))
+
+	ctx := context.Background()
+	resourceARN := "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:abc:autoScalingGroupName/test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test-asg"), PropagateAtLaunch: aws.Bool(true)},
+	}
+
+	mockClient.On("CreateOrUpdateTags", ctx, mock.Anything).Return(&svcsdk.CreateOrUpdateTagsOutput{}, nil)
+
+	err := syncer.SyncTags(ctx, desired, nil, resourceARN, resourceType)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSyncTags_PreflightDenies(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	mockIAM := &mockIAMClient{}
+
+	mockIAM.On("SimulatePrincipalPolicy", mock.Anything, mock.Anything).Return(
+		&svciam.SimulatePrincipalPolicyOutput{
+			EvaluationResults: []svciamtypes.EvaluationResult{
+				{EvalDecision: svciamtypes.PolicyEvaluationDecisionTypeExplicitDeny},
+			},
+		}, nil)
+
+	preflight := tags.NewPreflight(mockIAM, "arn:aws:iam::123456789012:role/ack-role")
+	syncer := tags.NewSyncer(mockClient, tags.WithPreflight(preflight))
+
+	ctx := context.Background()
+	resourceARN := "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:abc:autoScalingGroupName/test-asg"
+	resourceType := "auto-scaling-group"
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test-asg"), PropagateAtLaunch: aws.Bool(true)},
+	}
+
+	err := syncer.SyncTags(ctx, desired, nil, resourceARN, resourceType)
+
+	assert.Error(t, err)
+	var deniedErr *tags.PreflightDeniedError
+	assert.ErrorAs(t, err, &deniedErr)
+	mockClient.AssertNotCalled(t, "CreateOrUpdateTags", mock.Anything, mock.Anything)
+}
+
+func TestPreflightConfig_Preflight_DisabledReturnsNil(t *testing.T) {
+	cfg := &tags.PreflightConfig{}
+	assert.Nil(t, cfg.Preflight(&mockIAMClient{}))
+}
+
+func TestPreflightConfig_Preflight_EnabledBuildsPreflight(t *testing.T) {
+	cfg := &tags.PreflightConfig{Enabled: true, RoleARN: "arn:aws:iam::123456789012:role/ack-role"}
+	assert.NotNil(t, cfg.Preflight(&mockIAMClient{}))
+}
+
+func TestSyncer_FallsBackToDefaultPreflight(t *testing.T) {
+	mockClient := &mockTagsClient{}
+	mockIAM := &mockIAMClient{}
+
+	mockIAM.On("SimulatePrincipalPolicy", mock.Anything, mock.Anything).Return(
+		&svciam.SimulatePrincipalPolicyOutput{
+			EvaluationResults: []svciamtypes.EvaluationResult{
+				{EvalDecision: svciamtypes.PolicyEvaluationDecisionTypeExplicitDeny},
+			},
+		}, nil)
+
+	tags.SetDefaultPreflight(tags.NewPreflight(mockIAM, "arn:aws:iam::123456789012:role/ack-role"))
+	defer tags.SetDefaultPreflight(nil)
+
+	syncer := tags.NewSyncer(mockClient)
+	ctx := context.Background()
+	resourceARN := "arn:aws:autoscaling:us-west-2:123456789012:autoScalingGroup:abc:autoScalingGroupName/test-asg"
+
+	desired := []*svcapitypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test-asg"), PropagateAtLaunch: aws.Bool(true)},
+	}
+
+	err := syncer.SyncTags(ctx, desired, nil, resourceARN, "auto-scaling-group")
+
+	assert.Error(t, err)
+	var deniedErr *tags.PreflightDeniedError
+	assert.ErrorAs(t, err, &deniedErr)
+}
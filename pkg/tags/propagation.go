@@ -0,0 +1,320 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	svcec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+// DefaultLaunchTemplateVersionRetention is the number of non-default
+// launch template versions PropagationSyncer keeps around after rolling
+// out a new version, when no retention was configured.
+const DefaultLaunchTemplateVersionRetention = 5
+
+// EC2LaunchTemplateAPI represents the subset of the EC2 API used to mirror
+// ASG tags onto a launch template's tag specifications.
+type EC2LaunchTemplateAPI interface {
+	DescribeLaunchTemplateVersions(context.Context, *svcec2.DescribeLaunchTemplateVersionsInput, ...func(*svcec2.Options)) (*svcec2.DescribeLaunchTemplateVersionsOutput, error)
+	CreateLaunchTemplateVersion(context.Context, *svcec2.CreateLaunchTemplateVersionInput, ...func(*svcec2.Options)) (*svcec2.CreateLaunchTemplateVersionOutput, error)
+	ModifyLaunchTemplate(context.Context, *svcec2.ModifyLaunchTemplateInput, ...func(*svcec2.Options)) (*svcec2.ModifyLaunchTemplateOutput, error)
+	DeleteLaunchTemplateVersions(context.Context, *svcec2.DeleteLaunchTemplateVersionsInput, ...func(*svcec2.Options)) (*svcec2.DeleteLaunchTemplateVersionsOutput, error)
+}
+
+// PropagationConfig toggles which EC2 resource types a launch template's
+// tag specifications should receive propagated tags for. It mirrors the
+// per-resource-type switches on the AutoScalingGroup's spec.tagPropagation
+// field.
+type PropagationConfig struct {
+	Instance         bool
+	Volume           bool
+	NetworkInterface bool
+}
+
+// resourceTypes returns the EC2 resource types enabled by cfg, sorted for
+// deterministic output.
+func (cfg PropagationConfig) resourceTypes() []svcec2types.ResourceType {
+	var types []svcec2types.ResourceType
+	if cfg.Instance {
+		types = append(types, svcec2types.ResourceTypeInstance)
+	}
+	if cfg.Volume {
+		types = append(types, svcec2types.ResourceTypeVolume)
+	}
+	if cfg.NetworkInterface {
+		types = append(types, svcec2types.ResourceTypeNetworkInterface)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// PropagationSyncer mirrors AutoScalingGroup tags with PropagateAtLaunch
+// set onto the ASG's launch template, by pushing a new launch template
+// version whenever the desired tag specifications drift from the current
+// default version.
+type PropagationSyncer struct {
+	client    EC2LaunchTemplateAPI
+	retention int
+}
+
+// PropagationOption customizes a PropagationSyncer returned by
+// NewPropagationSyncer.
+type PropagationOption func(*PropagationSyncer)
+
+// WithRetention overrides the number of non-default launch template
+// versions kept after a new version is rolled out. Older versions beyond
+// this count are deleted.
+func WithRetention(n int) PropagationOption {
+	return func(s *PropagationSyncer) {
+		if n > 0 {
+			s.retention = n
+		}
+	}
+}
+
+// NewPropagationSyncer returns a new PropagationSyncer.
+func NewPropagationSyncer(client EC2LaunchTemplateAPI, opts ...PropagationOption) *PropagationSyncer {
+	s := &PropagationSyncer{client: client, retention: DefaultLaunchTemplateVersionRetention}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SyncLaunchTemplateTags ensures launchTemplateID's default version carries
+// the subset of tags with PropagateAtLaunch=true as tag specifications for
+// the resource types enabled in cfg. If the current default version's tag
+// specifications already match, no new version is created.
+func (s *PropagationSyncer) SyncLaunchTemplateTags(
+	ctx context.Context,
+	launchTemplateID string,
+	tags []*svcapitypes.Tag,
+	cfg PropagationConfig,
+) error {
+	resourceTypes := cfg.resourceTypes()
+	if len(resourceTypes) == 0 {
+		return nil
+	}
+
+	desiredSpecs := buildTagSpecifications(tags, resourceTypes)
+
+	describeOut, err := s.client.DescribeLaunchTemplateVersions(ctx, &svcec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(launchTemplateID),
+		Versions:         []string{"$Default"},
+	})
+	if err != nil {
+		return fmt.Errorf("describing launch template %s: %w", launchTemplateID, err)
+	}
+	if len(describeOut.LaunchTemplateVersions) == 0 {
+		return fmt.Errorf("launch template %s has no versions", launchTemplateID)
+	}
+	current := describeOut.LaunchTemplateVersions[0]
+
+	// Only the resource types this call manages participate in the drift
+	// comparison - current's specs for resource types outside cfg always
+	// differ in shape from desiredSpecs (which buildTagSpecifications never
+	// populates for them), and mergeTagSpecifications carries those
+	// unmanaged specs forward unchanged on every rollout regardless.
+	managedCurrent := filterTagSpecifications(current.LaunchTemplateData.TagSpecifications, resourceTypes)
+	if tagSpecificationsEqual(managedCurrent, desiredSpecs) {
+		return nil
+	}
+
+	// CreateLaunchTemplateVersion replaces the entire TagSpecifications
+	// field from the source version rather than merging per-resource-type,
+	// so any pre-existing specs for resource types this feature doesn't
+	// manage must be carried forward explicitly or they'd be silently
+	// wiped out on every rollout.
+	mergedSpecs := mergeTagSpecifications(current.LaunchTemplateData.TagSpecifications, desiredSpecs, resourceTypes)
+
+	createOut, err := s.client.CreateLaunchTemplateVersion(ctx, &svcec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId: aws.String(launchTemplateID),
+		SourceVersion:    aws.String("$Default"),
+		LaunchTemplateData: &svcec2types.RequestLaunchTemplateData{
+			TagSpecifications: mergedSpecs,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating new launch template version for %s: %w", launchTemplateID, err)
+	}
+
+	newVersion := createOut.LaunchTemplateVersion.VersionNumber
+	if _, err := s.client.ModifyLaunchTemplate(ctx, &svcec2.ModifyLaunchTemplateInput{
+		LaunchTemplateId: aws.String(launchTemplateID),
+		DefaultVersion:   aws.String(fmt.Sprintf("%d", aws.ToInt64(newVersion))),
+	}); err != nil {
+		return fmt.Errorf("setting default version on launch template %s: %w", launchTemplateID, err)
+	}
+
+	return s.pruneOldVersions(ctx, launchTemplateID)
+}
+
+// pruneOldVersions deletes the oldest non-default launch template versions
+// once more than s.retention have accumulated.
+func (s *PropagationSyncer) pruneOldVersions(ctx context.Context, launchTemplateID string) error {
+	out, err := s.client.DescribeLaunchTemplateVersions(ctx, &svcec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(launchTemplateID),
+	})
+	if err != nil {
+		return fmt.Errorf("listing versions of launch template %s: %w", launchTemplateID, err)
+	}
+
+	versions := out.LaunchTemplateVersions
+	sort.Slice(versions, func(i, j int) bool {
+		return aws.ToInt64(versions[i].VersionNumber) < aws.ToInt64(versions[j].VersionNumber)
+	})
+
+	var toPrune []string
+	for _, v := range versions {
+		if v.DefaultVersion != nil && *v.DefaultVersion {
+			continue
+		}
+		toPrune = append(toPrune, fmt.Sprintf("%d", aws.ToInt64(v.VersionNumber)))
+	}
+	if len(toPrune) <= s.retention {
+		return nil
+	}
+	toPrune = toPrune[:len(toPrune)-s.retention]
+
+	_, err = s.client.DeleteLaunchTemplateVersions(ctx, &svcec2.DeleteLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(launchTemplateID),
+		Versions:         toPrune,
+	})
+	if err != nil {
+		return fmt.Errorf("pruning old versions of launch template %s: %w", launchTemplateID, err)
+	}
+	return nil
+}
+
+// buildTagSpecifications returns one TagSpecification per resourceType,
+// each carrying the tags in tags that have PropagateAtLaunch set to true.
+// A resourceType with no such tags is omitted entirely rather than sent
+// with an empty Tags list.
+func buildTagSpecifications(
+	tags []*svcapitypes.Tag,
+	resourceTypes []svcec2types.ResourceType,
+) []svcec2types.LaunchTemplateTagSpecificationRequest {
+	var ec2Tags []svcec2types.Tag
+	for _, tag := range tags {
+		if tag.PropagateAtLaunch == nil || !*tag.PropagateAtLaunch || tag.Key == nil {
+			continue
+		}
+		ec2Tags = append(ec2Tags, svcec2types.Tag{Key: tag.Key, Value: tag.Value})
+	}
+	if len(ec2Tags) == 0 {
+		return nil
+	}
+
+	specs := make([]svcec2types.LaunchTemplateTagSpecificationRequest, 0, len(resourceTypes))
+	for _, rt := range resourceTypes {
+		specs = append(specs, svcec2types.LaunchTemplateTagSpecificationRequest{
+			ResourceType: rt,
+			Tags:         ec2Tags,
+		})
+	}
+	return specs
+}
+
+// mergeTagSpecifications returns the tag specifications to send to
+// CreateLaunchTemplateVersion: desired verbatim for the managed resource
+// types, plus current's specs carried forward unchanged for any resource
+// type not in managedTypes, so enabling propagation for one resource type
+// never clobbers tag specifications the caller set up for another.
+func mergeTagSpecifications(
+	current []svcec2types.LaunchTemplateTagSpecification,
+	desired []svcec2types.LaunchTemplateTagSpecificationRequest,
+	managedTypes []svcec2types.ResourceType,
+) []svcec2types.LaunchTemplateTagSpecificationRequest {
+	managed := map[svcec2types.ResourceType]bool{}
+	for _, rt := range managedTypes {
+		managed[rt] = true
+	}
+
+	merged := make([]svcec2types.LaunchTemplateTagSpecificationRequest, 0, len(current)+len(desired))
+	for _, spec := range current {
+		if managed[spec.ResourceType] {
+			continue
+		}
+		merged = append(merged, svcec2types.LaunchTemplateTagSpecificationRequest{
+			ResourceType: spec.ResourceType,
+			Tags:         spec.Tags,
+		})
+	}
+	merged = append(merged, desired...)
+	return merged
+}
+
+// filterTagSpecifications returns the entries in specs whose ResourceType is
+// in managedTypes, preserving order.
+func filterTagSpecifications(
+	specs []svcec2types.LaunchTemplateTagSpecification,
+	managedTypes []svcec2types.ResourceType,
+) []svcec2types.LaunchTemplateTagSpecification {
+	managed := map[svcec2types.ResourceType]bool{}
+	for _, rt := range managedTypes {
+		managed[rt] = true
+	}
+
+	var filtered []svcec2types.LaunchTemplateTagSpecification
+	for _, spec := range specs {
+		if managed[spec.ResourceType] {
+			filtered = append(filtered, spec)
+		}
+	}
+	return filtered
+}
+
+// tagSpecificationsEqual compares the tag specifications on an existing
+// launch template version against the desired request shape, ignoring
+// ordering of both resource types and tags within each.
+func tagSpecificationsEqual(
+	current []svcec2types.LaunchTemplateTagSpecification,
+	desired []svcec2types.LaunchTemplateTagSpecificationRequest,
+) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	currentByType := map[svcec2types.ResourceType]map[string]string{}
+	for _, spec := range current {
+		m := map[string]string{}
+		for _, t := range spec.Tags {
+			if t.Key != nil {
+				m[*t.Key] = aws.ToString(t.Value)
+			}
+		}
+		currentByType[spec.ResourceType] = m
+	}
+
+	for _, spec := range desired {
+		m, ok := currentByType[spec.ResourceType]
+		if !ok || len(m) != len(spec.Tags) {
+			return false
+		}
+		for _, t := range spec.Tags {
+			if t.Key == nil || m[*t.Key] != aws.ToString(t.Value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
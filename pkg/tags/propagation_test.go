@@ -0,0 +1,263 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws-controllers-k8s/autoscaling-controller/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	svcec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	svcapitypes "github.com/aws-controllers-k8s/autoscaling-controller/apis/v1alpha1"
+)
+
+type mockEC2Client struct {
+	mock.Mock
+}
+
+func (m *mockEC2Client) DescribeLaunchTemplateVersions(
+	ctx context.Context,
+	input *svcec2.DescribeLaunchTemplateVersionsInput,
+	opts ...func(*svcec2.Options),
+) (*svcec2.DescribeLaunchTemplateVersionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcec2.DescribeLaunchTemplateVersionsOutput), args.Error(1)
+}
+
+func (m *mockEC2Client) CreateLaunchTemplateVersion(
+	ctx context.Context,
+	input *svcec2.CreateLaunchTemplateVersionInput,
+	opts ...func(*svcec2.Options),
+) (*svcec2.CreateLaunchTemplateVersionOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcec2.CreateLaunchTemplateVersionOutput), args.Error(1)
+}
+
+func (m *mockEC2Client) ModifyLaunchTemplate(
+	ctx context.Context,
+	input *svcec2.ModifyLaunchTemplateInput,
+	opts ...func(*svcec2.Options),
+) (*svcec2.ModifyLaunchTemplateOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcec2.ModifyLaunchTemplateOutput), args.Error(1)
+}
+
+func (m *mockEC2Client) DeleteLaunchTemplateVersions(
+	ctx context.Context,
+	input *svcec2.DeleteLaunchTemplateVersionsInput,
+	opts ...func(*svcec2.Options),
+) (*svcec2.DeleteLaunchTemplateVersionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*svcec2.DeleteLaunchTemplateVersionsOutput), args.Error(1)
+}
+
+func TestPropagationSyncer_CreatesNewVersionOnDrift(t *testing.T) {
+	mockClient := &mockEC2Client{}
+	ctx := context.Background()
+	launchTemplateID := "lt-0123456789abcdef0"
+
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.MatchedBy(func(input *svcec2.DescribeLaunchTemplateVersionsInput) bool {
+		return len(input.Versions) == 1 && input.Versions[0] == "$Default"
+	})).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{
+				VersionNumber: aws.Int64(3),
+				LaunchTemplateData: &svcec2types.ResponseLaunchTemplateData{
+					TagSpecifications: []svcec2types.LaunchTemplateTagSpecification{},
+				},
+			},
+		},
+	}, nil).Once()
+
+	mockClient.On("CreateLaunchTemplateVersion", ctx, mock.MatchedBy(func(input *svcec2.CreateLaunchTemplateVersionInput) bool {
+		return len(input.LaunchTemplateData.TagSpecifications) == 1 &&
+			input.LaunchTemplateData.TagSpecifications[0].ResourceType == svcec2types.ResourceTypeVolume
+	})).Return(&svcec2.CreateLaunchTemplateVersionOutput{
+		LaunchTemplateVersion: &svcec2types.LaunchTemplateVersion{VersionNumber: aws.Int64(4)},
+	}, nil).Once()
+
+	mockClient.On("ModifyLaunchTemplate", ctx, mock.MatchedBy(func(input *svcec2.ModifyLaunchTemplateInput) bool {
+		return aws.ToString(input.DefaultVersion) == "4"
+	})).Return(&svcec2.ModifyLaunchTemplateOutput{}, nil).Once()
+
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.MatchedBy(func(input *svcec2.DescribeLaunchTemplateVersionsInput) bool {
+		return len(input.Versions) == 0
+	})).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{VersionNumber: aws.Int64(4), DefaultVersion: aws.Bool(true)},
+			{VersionNumber: aws.Int64(3), DefaultVersion: aws.Bool(false)},
+		},
+	}, nil).Once()
+
+	syncer := tags.NewPropagationSyncer(mockClient)
+	err := syncer.SyncLaunchTemplateTags(ctx, launchTemplateID, []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("prod"), PropagateAtLaunch: aws.Bool(true)},
+		{Key: aws.String("Internal"), Value: aws.String("x"), PropagateAtLaunch: aws.Bool(false)},
+	}, tags.PropagationConfig{Volume: true})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPropagationSyncer_NoopWhenNoDrift(t *testing.T) {
+	mockClient := &mockEC2Client{}
+	ctx := context.Background()
+	launchTemplateID := "lt-0123456789abcdef0"
+
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.Anything).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{
+				VersionNumber: aws.Int64(3),
+				LaunchTemplateData: &svcec2types.ResponseLaunchTemplateData{
+					TagSpecifications: []svcec2types.LaunchTemplateTagSpecification{
+						{
+							ResourceType: svcec2types.ResourceTypeVolume,
+							Tags:         []svcec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}},
+						},
+					},
+				},
+			},
+		},
+	}, nil).Once()
+
+	syncer := tags.NewPropagationSyncer(mockClient)
+	err := syncer.SyncLaunchTemplateTags(ctx, launchTemplateID, []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("prod"), PropagateAtLaunch: aws.Bool(true)},
+	}, tags.PropagationConfig{Volume: true})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CreateLaunchTemplateVersion", mock.Anything, mock.Anything)
+}
+
+func TestPropagationSyncer_PreservesUnmanagedResourceTypeTagSpecs(t *testing.T) {
+	mockClient := &mockEC2Client{}
+	ctx := context.Background()
+	launchTemplateID := "lt-0123456789abcdef0"
+
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.MatchedBy(func(input *svcec2.DescribeLaunchTemplateVersionsInput) bool {
+		return len(input.Versions) == 1 && input.Versions[0] == "$Default"
+	})).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{
+				VersionNumber: aws.Int64(3),
+				LaunchTemplateData: &svcec2types.ResponseLaunchTemplateData{
+					TagSpecifications: []svcec2types.LaunchTemplateTagSpecification{
+						{
+							ResourceType: svcec2types.ResourceTypeInstance,
+							Tags:         []svcec2types.Tag{{Key: aws.String("PreExisting"), Value: aws.String("kept")}},
+						},
+					},
+				},
+			},
+		},
+	}, nil).Once()
+
+	mockClient.On("CreateLaunchTemplateVersion", ctx, mock.MatchedBy(func(input *svcec2.CreateLaunchTemplateVersionInput) bool {
+		specs := input.LaunchTemplateData.TagSpecifications
+		if len(specs) != 2 {
+			return false
+		}
+		var sawInstance, sawVolume bool
+		for _, spec := range specs {
+			switch spec.ResourceType {
+			case svcec2types.ResourceTypeInstance:
+				sawInstance = len(spec.Tags) == 1 && aws.ToString(spec.Tags[0].Key) == "PreExisting"
+			case svcec2types.ResourceTypeVolume:
+				sawVolume = len(spec.Tags) == 1 && aws.ToString(spec.Tags[0].Key) == "Environment"
+			}
+		}
+		return sawInstance && sawVolume
+	})).Return(&svcec2.CreateLaunchTemplateVersionOutput{
+		LaunchTemplateVersion: &svcec2types.LaunchTemplateVersion{VersionNumber: aws.Int64(4)},
+	}, nil).Once()
+
+	mockClient.On("ModifyLaunchTemplate", ctx, mock.MatchedBy(func(input *svcec2.ModifyLaunchTemplateInput) bool {
+		return aws.ToString(input.DefaultVersion) == "4"
+	})).Return(&svcec2.ModifyLaunchTemplateOutput{}, nil).Once()
+
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.MatchedBy(func(input *svcec2.DescribeLaunchTemplateVersionsInput) bool {
+		return len(input.Versions) == 0
+	})).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{VersionNumber: aws.Int64(4), DefaultVersion: aws.Bool(true)},
+			{VersionNumber: aws.Int64(3), DefaultVersion: aws.Bool(false)},
+		},
+	}, nil).Once()
+
+	syncer := tags.NewPropagationSyncer(mockClient)
+	err := syncer.SyncLaunchTemplateTags(ctx, launchTemplateID, []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("prod"), PropagateAtLaunch: aws.Bool(true)},
+	}, tags.PropagationConfig{Volume: true})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPropagationSyncer_NoopOnSecondReconcileAfterMergingUnmanagedSpecs(t *testing.T) {
+	mockClient := &mockEC2Client{}
+	ctx := context.Background()
+	launchTemplateID := "lt-0123456789abcdef0"
+
+	// The default version already carries both the merged, managed Volume
+	// spec from a prior rollout and an untouched Instance spec this call
+	// doesn't manage - the same shape SyncLaunchTemplateTags itself would
+	// have produced. This must be a no-op.
+	mockClient.On("DescribeLaunchTemplateVersions", ctx, mock.MatchedBy(func(input *svcec2.DescribeLaunchTemplateVersionsInput) bool {
+		return len(input.Versions) == 1 && input.Versions[0] == "$Default"
+	})).Return(&svcec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []svcec2types.LaunchTemplateVersion{
+			{
+				VersionNumber: aws.Int64(4),
+				LaunchTemplateData: &svcec2types.ResponseLaunchTemplateData{
+					TagSpecifications: []svcec2types.LaunchTemplateTagSpecification{
+						{
+							ResourceType: svcec2types.ResourceTypeInstance,
+							Tags:         []svcec2types.Tag{{Key: aws.String("PreExisting"), Value: aws.String("kept")}},
+						},
+						{
+							ResourceType: svcec2types.ResourceTypeVolume,
+							Tags:         []svcec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}},
+						},
+					},
+				},
+			},
+		},
+	}, nil).Once()
+
+	syncer := tags.NewPropagationSyncer(mockClient)
+	err := syncer.SyncLaunchTemplateTags(ctx, launchTemplateID, []*svcapitypes.Tag{
+		{Key: aws.String("Environment"), Value: aws.String("prod"), PropagateAtLaunch: aws.Bool(true)},
+	}, tags.PropagationConfig{Volume: true})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CreateLaunchTemplateVersion", mock.Anything, mock.Anything)
+}
+
+func TestPropagationSyncer_NoResourceTypesEnabled(t *testing.T) {
+	mockClient := &mockEC2Client{}
+	ctx := context.Background()
+
+	syncer := tags.NewPropagationSyncer(mockClient)
+	err := syncer.SyncLaunchTemplateTags(ctx, "lt-0123456789abcdef0", nil, tags.PropagationConfig{})
+
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "DescribeLaunchTemplateVersions", mock.Anything, mock.Anything)
+}
@@ -15,6 +15,8 @@ package tags
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/autoscaling"
@@ -32,12 +34,26 @@ type TagsServiceAPI interface {
 
 // Syncer handles syncing tags between the ACK resource and the AWS resource
 type Syncer struct {
-	client TagsServiceAPI
+	client      TagsServiceAPI
+	batchSize   int
+	policy      *Policy
+	preflight   *Preflight
+	ignoreRules *IgnoreRules
 }
 
 // NewSyncer returns a new Syncer object
-func NewSyncer(client TagsServiceAPI) *Syncer {
-	return &Syncer{client: client}
+func NewSyncer(client TagsServiceAPI, opts ...Option) *Syncer {
+	s := &Syncer{
+		client:      client,
+		batchSize:   DefaultBatchSize,
+		policy:      DefaultPolicy(),
+		preflight:   DefaultPreflight(),
+		ignoreRules: DefaultIgnoreRules(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetTags returns the tags for a given resource
@@ -73,7 +89,9 @@ func (s *Syncer) GetTags(
 	return tags, nil
 }
 
-// SyncTags synchronizes tags between the ACK resource and the AWS resource
+// SyncTags synchronizes tags between the ACK resource and the AWS resource.
+// When the Syncer was constructed WithPreflight, resourceID must be the
+// resource's full ARN so it can be passed to IAM SimulatePrincipalPolicy.
 func (s *Syncer) SyncTags(
 	ctx context.Context,
 	desired []*svcapitypes.Tag,
@@ -81,9 +99,19 @@ func (s *Syncer) SyncTags(
 	resourceID string,
 	resourceType string,
 ) error {
+	// Tags matching the ignore rules are managed by an external tagger, not
+	// ACK, so they never participate in the create/update/delete diff.
+	desired = s.ignoreRules.Filter(desired)
+	latest = s.ignoreRules.Filter(latest)
+
+	desired, err := s.policy.Enforce(desired)
+	if err != nil {
+		return err
+	}
+
 	// If there are no desired tags, delete all existing tags
 	if len(desired) == 0 && len(latest) > 0 {
-		return s.deleteTags(ctx, latest, resourceID, resourceType)
+		return s.deleteTags(ctx, s.policy.ProtectRequired(latest), resourceID, resourceType)
 	}
 
 	// If there are no latest tags, create all desired tags
@@ -133,7 +161,8 @@ func (s *Syncer) SyncTags(
 		}
 	}
 
-	// Process deletions
+	// Process deletions, never removing a tag the required-tags policy mandates
+	toDelete = s.policy.ProtectRequired(toDelete)
 	if len(toDelete) > 0 {
 		if err := s.deleteTags(ctx, toDelete, resourceID, resourceType); err != nil {
 			return err
@@ -162,6 +191,10 @@ func (s *Syncer) createTags(
 		return nil
 	}
 
+	if err := s.preflight.checkTagMutation(ctx, autoscalingCreateOrUpdateTagsAction, resourceID, tags); err != nil {
+		return err
+	}
+
 	sdkTags := []svcsdktypes.Tag{}
 	for _, tag := range tags {
 		// Ensure we have the resource ID and type set
@@ -188,17 +221,20 @@ func (s *Syncer) createTags(
 		sdkTags = append(sdkTags, sdkTag)
 	}
 
-	_, err := s.client.CreateOrUpdateTags(
-		ctx,
-		&svcsdk.CreateOrUpdateTagsInput{
-			Tags: sdkTags,
-		},
-	)
-	if err != nil {
-		return err
+	var errs []error
+	for batchNum, batch := range chunkTags(sdkTags, s.batchSize) {
+		_, err := s.client.CreateOrUpdateTags(
+			ctx,
+			&svcsdk.CreateOrUpdateTagsInput{
+				Tags: batch,
+			},
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create/update tags batch %d (%d tags): %w", batchNum+1, len(batch), err))
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // deleteTags deletes tags from a resource
@@ -212,6 +248,10 @@ func (s *Syncer) deleteTags(
 		return nil
 	}
 
+	if err := s.preflight.checkTagMutation(ctx, autoscalingDeleteTagsAction, resourceID, tags); err != nil {
+		return err
+	}
+
 	sdkTags := []svcsdktypes.Tag{}
 	for _, tag := range tags {
 		// Ensure we have the resource ID and type set
@@ -231,17 +271,38 @@ func (s *Syncer) deleteTags(
 		sdkTags = append(sdkTags, sdkTag)
 	}
 
-	_, err := s.client.DeleteTags(
-		ctx,
-		&svcsdk.DeleteTagsInput{
-			Tags: sdkTags,
-		},
-	)
-	if err != nil {
-		return err
+	var errs []error
+	for batchNum, batch := range chunkTags(sdkTags, s.batchSize) {
+		_, err := s.client.DeleteTags(
+			ctx,
+			&svcsdk.DeleteTagsInput{
+				Tags: batch,
+			},
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete tags batch %d (%d tags): %w", batchNum+1, len(batch), err))
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// chunkTags splits tags into consecutive batches of at most size entries.
+func chunkTags(tags []svcsdktypes.Tag, size int) [][]svcsdktypes.Tag {
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	var batches [][]svcsdktypes.Tag
+	for start := 0; start < len(tags); start += size {
+		end := start + size
+		if end > len(tags) {
+			end = len(tags)
+		}
+		batches = append(batches, tags[start:end])
+	}
+
+	return batches
 }
 
 // tagEquals returns true if the two tags are equal